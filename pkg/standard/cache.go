@@ -0,0 +1,43 @@
+package standard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+)
+
+// CacheEntry is the persisted record kept for a single canonicalized URL
+// across incremental recrawls.
+type CacheEntry struct {
+	ETag         string   `json:"etag,omitempty"`
+	LastModified string   `json:"last_modified,omitempty"`
+	BodyHash     string   `json:"body_hash,omitempty"`
+	Outlinks     []string `json:"outlinks,omitempty"`
+}
+
+// Store is implemented by the persistent backends that keep track of
+// previously crawled URLs for incremental recrawls. BoltStore is the
+// implementation in this package; the crawler always opens a BoltStore at
+// the Options.ResumeCache path and has no option for using another Store.
+type Store interface {
+	Get(key string) (*CacheEntry, bool)
+	Put(key string, entry *CacheEntry) error
+	Close() error
+}
+
+// canonicalizeURL normalizes a URL for use as a cache key, stripping the
+// fragment which has no bearing on the server response.
+func canonicalizeURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+// bodySHA256 returns the hex-encoded SHA-256 digest of a response body.
+func bodySHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,70 @@
+package standard
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// cacheBucket is the single bbolt bucket resume cache entries are stored in.
+var cacheBucket = []byte("katana-resume-cache")
+
+// BoltStore is the default bbolt-backed implementation of Store, persisting
+// cache entries to a single file on disk.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a bbolt-backed resume cache at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0644, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open resume cache")
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(cacheBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create resume cache bucket")
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Get returns the cached entry for key, if any.
+func (s *BoltStore) Get(key string) (*CacheEntry, bool) {
+	var entry CacheEntry
+	found := false
+	_ = s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(cacheBucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+		found = true
+		return nil
+	})
+	if !found {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Put persists entry for key.
+func (s *BoltStore) Put(key string, entry *CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(cacheBucket).Put([]byte(key), data)
+	})
+}
+
+// Close closes the underlying bbolt database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
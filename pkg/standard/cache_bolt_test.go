@@ -0,0 +1,49 @@
+package standard
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltStoreGetPutRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume-cache.db")
+
+	store, err := NewBoltStore(path)
+	require.NoError(t, err, "could not open bolt store")
+	defer store.Close()
+
+	if _, ok := store.Get("https://example.com/"); ok {
+		t.Fatalf("expected no cached entry for an unseen key")
+	}
+
+	entry := &CacheEntry{
+		ETag:         `"abc123"`,
+		LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+		BodyHash:     "deadbeef",
+		Outlinks:     []string{"https://example.com/a", "https://example.com/b"},
+	}
+	require.NoError(t, store.Put("https://example.com/", entry), "could not put cache entry")
+
+	got, ok := store.Get("https://example.com/")
+	require.True(t, ok, "expected cached entry to be found")
+	require.Equal(t, entry, got, "round-tripped entry should match what was stored")
+}
+
+func TestBoltStoreReopenPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume-cache.db")
+
+	store, err := NewBoltStore(path)
+	require.NoError(t, err, "could not open bolt store")
+	require.NoError(t, store.Put("https://example.com/", &CacheEntry{ETag: `"v1"`}))
+	require.NoError(t, store.Close())
+
+	reopened, err := NewBoltStore(path)
+	require.NoError(t, err, "could not reopen bolt store")
+	defer reopened.Close()
+
+	got, ok := reopened.Get("https://example.com/")
+	require.True(t, ok, "expected entry to survive reopening the store")
+	require.Equal(t, `"v1"`, got.ETag)
+}
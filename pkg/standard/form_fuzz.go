@@ -0,0 +1,125 @@
+package standard
+
+import (
+	"io/ioutil"
+	"sync"
+
+	"github.com/projectdiscovery/katana/pkg/navigation"
+	"github.com/projectdiscovery/katana/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// FormFuzzWordlist maps a form input's name (preferred) or type to the list
+// of payload values substituted into it during form fuzzing, e.g.:
+//
+//	email: [a@b.c, '"><svg>']
+//	search: ["{{XSS}}", "{{SQLI}}"]
+type FormFuzzWordlist map[string][]string
+
+// valuesFor returns the payload values configured for a form input, keyed
+// first by its name and falling back to its type.
+func (w FormFuzzWordlist) valuesFor(input utils.FormInput) ([]string, bool) {
+	if values, ok := w[input.Name]; ok && len(values) > 0 {
+		return values, true
+	}
+	if values, ok := w[input.Type]; ok && len(values) > 0 {
+		return values, true
+	}
+	return nil, false
+}
+
+// formFuzzWordlists caches loaded wordlists by path so repeated forms
+// across a crawl don't re-read and re-parse the file.
+var formFuzzWordlists sync.Map
+
+// loadFormFuzzWordlistForResponse returns the form fuzzing wordlist
+// configured for resp's crawl, or nil if fuzzing isn't enabled or the
+// wordlist couldn't be loaded.
+func loadFormFuzzWordlistForResponse(resp navigation.NavigationResponse) FormFuzzWordlist {
+	path := resp.Options.Options.FormFuzzWordlist
+	if path == "" {
+		return nil
+	}
+	if cached, ok := formFuzzWordlists.Load(path); ok {
+		return cached.(FormFuzzWordlist)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var wordlist FormFuzzWordlist
+	if err := yaml.Unmarshal(data, &wordlist); err != nil {
+		return nil
+	}
+	formFuzzWordlists.Store(path, wordlist)
+	return wordlist
+}
+
+// formValueCombo is a single cartesian-product combination of a form's
+// default field values with one or more wordlist-driven payloads.
+type formValueCombo struct {
+	values map[string]string
+	tag    string
+}
+
+// cartesianFormValues expands a form's default fill values into the
+// cartesian product of wordlist-driven payloads across its fuzzable
+// inputs, tagging each combination with the field=payload pairs that were
+// substituted into it. Inputs without a wordlist entry keep their default
+// suggested value in every combination.
+func cartesianFormValues(inputs []utils.FormInput, defaults map[string]string, wordlist FormFuzzWordlist) []formValueCombo {
+	type fuzzField struct {
+		key    string
+		values []string
+	}
+
+	var fields []fuzzField
+	seen := make(map[string]struct{})
+	for _, input := range inputs {
+		if input.Name == "" {
+			continue
+		}
+		if _, ok := seen[input.Name]; ok {
+			continue
+		}
+		values, ok := wordlist.valuesFor(input)
+		if !ok {
+			continue
+		}
+		seen[input.Name] = struct{}{}
+		fields = append(fields, fuzzField{key: input.Name, values: values})
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	combos := []formValueCombo{{values: cloneFormValues(defaults)}}
+	for _, field := range fields {
+		var next []formValueCombo
+		for _, combo := range combos {
+			for _, value := range field.values {
+				values := cloneFormValues(combo.values)
+				values[field.key] = value
+
+				tag := field.key + "=" + value
+				if combo.tag != "" {
+					tag = combo.tag + "," + tag
+				}
+				next = append(next, formValueCombo{values: values, tag: tag})
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// cloneFormValues returns a shallow copy of a form's field values so
+// cartesian expansion can mutate each combination independently.
+func cloneFormValues(values map[string]string) map[string]string {
+	cloned := make(map[string]string, len(values))
+	for k, v := range values {
+		cloned[k] = v
+	}
+	return cloned
+}
@@ -0,0 +1,87 @@
+package standard
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/projectdiscovery/katana/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartesianFormValues(t *testing.T) {
+	inputs := []utils.FormInput{
+		{Name: "email", Type: "email"},
+		{Name: "search", Type: "text"},
+		{Name: "csrf_token", Type: "hidden", Value: "fixed-token"},
+	}
+	defaults := map[string]string{
+		"email":      "email@example.com",
+		"search":     "katana",
+		"csrf_token": "fixed-token",
+	}
+	wordlist := FormFuzzWordlist{
+		"email":  {"a@b.c", "x@y.z"},
+		"search": {"{{XSS}}"},
+	}
+
+	combos := cartesianFormValues(inputs, defaults, wordlist)
+	require.Len(t, combos, 2, "expected one combination per email payload, search has a single value")
+
+	var tags []string
+	for _, combo := range combos {
+		tags = append(tags, combo.tag)
+		require.Equal(t, "{{XSS}}", combo.values["search"], "search should always use its one wordlist value")
+		require.Equal(t, "fixed-token", combo.values["csrf_token"], "inputs without a wordlist entry should keep their default value")
+	}
+	sort.Strings(tags)
+	require.Equal(t, []string{"email=a@b.c,search={{XSS}}", "email=x@y.z,search={{XSS}}"}, tags)
+}
+
+func TestCartesianFormValuesMultiField(t *testing.T) {
+	inputs := []utils.FormInput{
+		{Name: "a", Type: "text"},
+		{Name: "b", Type: "text"},
+	}
+	defaults := map[string]string{"a": "default-a", "b": "default-b"}
+	wordlist := FormFuzzWordlist{
+		"a": {"1", "2"},
+		"b": {"x", "y"},
+	}
+
+	combos := cartesianFormValues(inputs, defaults, wordlist)
+	require.Len(t, combos, 4, "expected the full cartesian product of both fuzzed fields")
+
+	seen := make(map[string]struct{})
+	for _, combo := range combos {
+		seen[combo.values["a"]+"/"+combo.values["b"]] = struct{}{}
+	}
+	require.Len(t, seen, 4, "expected 4 distinct field-value combinations")
+}
+
+func TestCartesianFormValuesNoWordlistMatch(t *testing.T) {
+	inputs := []utils.FormInput{{Name: "unrelated", Type: "text"}}
+	defaults := map[string]string{"unrelated": "default"}
+	wordlist := FormFuzzWordlist{"other": {"payload"}}
+
+	combos := cartesianFormValues(inputs, defaults, wordlist)
+	require.Nil(t, combos, "expected no combinations when no input matches the wordlist")
+}
+
+func TestCartesianFormValuesRespectsCallerCap(t *testing.T) {
+	inputs := []utils.FormInput{{Name: "q", Type: "text"}}
+	defaults := map[string]string{"q": "default"}
+	wordlist := FormFuzzWordlist{"q": {"p1", "p2", "p3", "p4"}}
+
+	combos := cartesianFormValues(inputs, defaults, wordlist)
+	require.Len(t, combos, 4)
+
+	// cartesianFormValues returns the full product and leaves capping to its
+	// caller; the truncation below mirrors the FormFuzzMax check in
+	// bodyFormTagParser's loop rather than calling it.
+	const max = 2
+	capped := combos
+	if len(capped) > max {
+		capped = capped[:max]
+	}
+	require.Len(t, capped, max, "caller-side FormFuzzMax capping should truncate the combination list")
+}
@@ -11,8 +11,11 @@ import (
 )
 
 // responseParserFunc is a function that parses the document returning
-// new navigation items or requests for the crawler.
-type responseParserFunc func(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest))
+// new navigation items or requests for the crawler. base is the document's
+// effective base URL (the href of a <base> tag when the document declares
+// one, the response's own URL otherwise) that body parsers must resolve
+// relative links against.
+type responseParserFunc func(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest))
 
 // responseParsers is a list of response parsers for the standard engine
 var responseParsers = []responseParserFunc{
@@ -22,7 +25,10 @@ var responseParsers = []responseParserFunc{
 	headerLocationParser,
 	headerRefreshParser,
 
-	// Body based parsers
+	// Body based parsers. base is resolved once for the whole document in
+	// parseResponse, so the position of bodyBaseHrefTagParser in this list
+	// does not affect how other parsers resolve links.
+	bodyBaseHrefTagParser,
 	bodyATagParser,
 	bodyEmbedTagParser,
 	bodyFrameTagParser,
@@ -32,6 +38,13 @@ var responseParsers = []responseParserFunc{
 	bodyScriptSrcTagParser,
 	bodyFormTagParser,
 	bodyMetaContentTagParser,
+	bodyImgSrcsetTagParser,
+	bodyLinkHrefTagParser,
+	bodyObjectDataTagParser,
+	bodyAppletTagParser,
+	bodyMediaSrcTagParser,
+	bodyAreaHrefTagParser,
+	bodyMetaCSPTagParser,
 
 	// Optional JS relative endpoints parsers
 	scriptContentRegexParser,
@@ -40,8 +53,61 @@ var responseParsers = []responseParserFunc{
 
 // parseResponse runs the response parsers on the navigation response
 func parseResponse(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+	base := documentBaseURL(resp)
 	for _, parser := range responseParsers {
-		parser(resp, callback)
+		parser(resp, base, callback)
+	}
+}
+
+// documentBaseURL returns the effective base URL that relative links in
+// resp's body must be resolved against: the href of a <base> tag if the
+// document declares one, falling back to the response's own request URL.
+func documentBaseURL(resp navigation.NavigationResponse) *url.URL {
+	if href, ok := resp.Reader.Find("base[href]").First().Attr("href"); ok && href != "" {
+		if resolved, err := url.Parse(resp.AbsoluteURL(href)); err == nil {
+			return resolved
+		}
+	}
+	if resp.Resp != nil && resp.Resp.Request != nil && resp.Resp.Request.URL != nil {
+		return resp.Resp.Request.URL
+	}
+	return &url.URL{}
+}
+
+// resolveHref resolves href against base, returning an empty string if
+// href is empty or cannot be parsed.
+func resolveHref(base *url.URL, href string) string {
+	if href == "" {
+		return ""
+	}
+	parsed, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(parsed).String()
+}
+
+// newRequest builds a GET NavigationRequest for a resolved URL.
+func newRequest(resolvedURL, source string, resp navigation.NavigationResponse) navigation.NavigationRequest {
+	return navigation.NavigationRequest{Method: "GET", URL: resolvedURL, Source: source, Depth: resp.Depth}
+}
+
+// findAttrParser returns a responseParserFunc that, for every element
+// matching selector, resolves attr against the document's base URL and
+// emits it as a navigation request tagged with source.
+func findAttrParser(selector, attr, source string) responseParserFunc {
+	return func(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+		resp.Reader.Find(selector).Each(func(i int, item *goquery.Selection) {
+			value, ok := item.Attr(attr)
+			if !ok || value == "" {
+				return
+			}
+			resolved := resolveHref(base, value)
+			if resolved == "" {
+				return
+			}
+			callback(newRequest(resolved, source, resp))
+		})
 	}
 }
 
@@ -50,7 +116,7 @@ func parseResponse(resp navigation.NavigationResponse, callback func(navigation.
 // -------------------------------------------------------------------------
 
 // headerContentLocationParser parsers Content-Location header from response
-func headerContentLocationParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func headerContentLocationParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	header := resp.Resp.Header.Get("Content-Location")
 	if header == "" {
 		return
@@ -59,7 +125,7 @@ func headerContentLocationParser(resp navigation.NavigationResponse, callback fu
 }
 
 // headerLinkParser parsers Link header from response
-func headerLinkParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func headerLinkParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	header := resp.Resp.Header.Get("Link")
 	if header == "" {
 		return
@@ -71,7 +137,7 @@ func headerLinkParser(resp navigation.NavigationResponse, callback func(navigati
 }
 
 // headerLocationParser parsers Location header from response
-func headerLocationParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func headerLocationParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	header := resp.Resp.Header.Get("Location")
 	if header == "" {
 		return
@@ -80,7 +146,7 @@ func headerLocationParser(resp navigation.NavigationResponse, callback func(navi
 }
 
 // headerRefreshParser parsers Refresh header from response
-func headerRefreshParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func headerRefreshParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	header := resp.Resp.Header.Get("Refresh")
 	if header == "" {
 		return
@@ -97,91 +163,61 @@ func headerRefreshParser(resp navigation.NavigationResponse, callback func(navig
 // -------------------------------------------------------------------------
 
 // bodyATagParser parses A tag from response
-func bodyATagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func bodyATagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	resp.Reader.Find("a").Each(func(i int, item *goquery.Selection) {
-		href, ok := item.Attr("href")
-		if ok && href != "" {
-			callback(navigation.NewNavigationRequestURL(href, "a", resp))
+		if href, ok := item.Attr("href"); ok {
+			if resolved := resolveHref(base, href); resolved != "" {
+				callback(newRequest(resolved, "a", resp))
+			}
 		}
-		ping, ok := item.Attr("ping")
-		if ok && ping != "" {
-			callback(navigation.NewNavigationRequestURL(ping, "a", resp))
+		if ping, ok := item.Attr("ping"); ok {
+			if resolved := resolveHref(base, ping); resolved != "" {
+				callback(newRequest(resolved, "a", resp))
+			}
 		}
 	})
 }
 
 // bodyEmbedTagParser parses Embed tag from response
-func bodyEmbedTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("embed[src]").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("src")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "embed", resp))
-		}
-	})
+func bodyEmbedTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("embed[src]", "src", "embed")(resp, base, callback)
 }
 
 // bodyFrameTagParser parses frame tag from response
-func bodyFrameTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("frame[src]").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("src")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "frame", resp))
-		}
-	})
+func bodyFrameTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("frame[src]", "src", "frame")(resp, base, callback)
 }
 
 // bodyIframeTagParser parses iframe tag from response
-func bodyIframeTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("iframe[src]").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("src")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "iframe", resp))
-		}
-	})
+func bodyIframeTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("iframe[src]", "src", "iframe")(resp, base, callback)
 }
 
 // bodyInputSrcTagParser parses input image src tag from response
-func bodyInputSrcTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("input[type='image']").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("src")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "input", resp))
-		}
-	})
+func bodyInputSrcTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("input[type='image']", "src", "input")(resp, base, callback)
 }
 
 // bodyIsindexActionTagParser parses isindex action tag from response
-func bodyIsindexActionTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("isindex[action]").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("action")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "isindex", resp))
-		}
-	})
+func bodyIsindexActionTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("isindex[action]", "action", "isindex")(resp, base, callback)
 }
 
 // bodyScriptSrcTagParser parses script src tag from response
-func bodyScriptSrcTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("script[src]").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("src")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "script", resp))
-		}
-	})
+func bodyScriptSrcTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("script[src]", "src", "script")(resp, base, callback)
 }
 
 // bodyButtonFormactionTagParser parses button formaction tag from response
-func bodyButtonFormactionTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
-	resp.Reader.Find("button[formaction]").Each(func(i int, item *goquery.Selection) {
-		src, ok := item.Attr("formaction")
-		if ok && src != "" {
-			callback(navigation.NewNavigationRequestURL(src, "button", resp))
-		}
-	})
+func bodyButtonFormactionTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("button[formaction]", "formaction", "button")(resp, base, callback)
 }
 
-// bodyFormTagParser parses forms from response
-func bodyFormTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+// bodyFormTagParser parses forms from response. When form fuzzing is
+// enabled via Options.FormFuzzWordlist, each form fans out into one request
+// per combination of wordlist-driven payload values instead of a single
+// submission.
+func bodyFormTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	resp.Reader.Find("form[action]").Each(func(i int, item *goquery.Selection) {
 		href, ok := item.Attr("action")
 		if !ok {
@@ -198,24 +234,16 @@ func bodyFormTagParser(resp navigation.NavigationResponse, callback func(navigat
 		}
 		method = strings.ToUpper(method)
 
-		actionURL := resp.AbsoluteURL(href)
+		actionURL := resolveHref(base, href)
 		if actionURL == "" {
 			return
 		}
 
 		isMultipartForm := strings.HasPrefix(encType, "multipart/")
 
-		queryValuesWriter := make(url.Values)
-		var sb strings.Builder
-		var multipartWriter *multipart.Writer
-
-		if isMultipartForm {
-			multipartWriter = multipart.NewWriter(&sb)
-		}
-
-		// Get the form field suggestions for all inputs
+		// Get the form field suggestions for all inputs, selects and textareas
 		formInputs := []utils.FormInput{}
-		item.Find("input").Each(func(index int, item *goquery.Selection) {
+		item.Find("input, select, textarea").Each(func(index int, item *goquery.Selection) {
 			if len(item.Nodes) == 0 {
 				return
 			}
@@ -223,55 +251,90 @@ func bodyFormTagParser(resp navigation.NavigationResponse, callback func(navigat
 		})
 
 		dataMap := utils.FormInputFillSuggestions(formInputs, utils.DefaultFormFillData)
-		for key, value := range dataMap {
-			if key == "" || value == "" {
-				continue
-			}
-			if isMultipartForm {
-				_ = multipartWriter.WriteField(key, value)
-			} else {
-				queryValuesWriter.Set(key, value)
-			}
+
+		wordlist := loadFormFuzzWordlistForResponse(resp)
+		if wordlist == nil {
+			callback(buildFormRequest(resp, actionURL, method, isMultipartForm, encType, dataMap, "form", ""))
+			return
 		}
 
-		// Guess content-type
-		var contentType string
-		if multipartWriter != nil {
-			multipartWriter.Close()
-			contentType = multipartWriter.FormDataContentType()
-		} else {
-			contentType = encType
+		combos := cartesianFormValues(formInputs, dataMap, wordlist)
+		if len(combos) == 0 {
+			callback(buildFormRequest(resp, actionURL, method, isMultipartForm, encType, dataMap, "form", ""))
+			return
 		}
 
-		req := navigation.NavigationRequest{
-			Method: method,
-			URL:    actionURL,
-			Depth:  resp.Depth,
-			Source: "form",
+		max := resp.Options.Options.FormFuzzMax
+		for i, combo := range combos {
+			if max > 0 && i >= max {
+				break
+			}
+			callback(buildFormRequest(resp, actionURL, method, isMultipartForm, encType, combo.values, "form-fuzz", combo.tag))
 		}
-		if multipartWriter != nil {
-			req.Body = sb.String()
-		} else {
-			req.Body = queryValuesWriter.Encode()
+	})
+}
+
+// buildFormRequest builds a single NavigationRequest from a form's action,
+// method and fully-resolved field values, reusing the urlencoded/multipart
+// encoding logic for both single-submission and fuzzed submissions.
+func buildFormRequest(resp navigation.NavigationResponse, actionURL, method string, isMultipartForm bool, encType string, values map[string]string, source, tag string) navigation.NavigationRequest {
+	queryValuesWriter := make(url.Values)
+	var sb strings.Builder
+	var multipartWriter *multipart.Writer
+
+	if isMultipartForm {
+		multipartWriter = multipart.NewWriter(&sb)
+	}
+
+	for key, value := range values {
+		if key == "" || value == "" {
+			continue
 		}
-		switch method {
-		case "GET":
-			value := queryValuesWriter.Encode()
-			sb.Reset()
-			sb.WriteString(req.URL)
-			sb.WriteString("?")
-			sb.WriteString(value)
-			req.URL = sb.String()
-		case "POST":
-			req.Headers = make(map[string]string)
-			req.Headers["Content-Type"] = contentType
+		if isMultipartForm {
+			_ = multipartWriter.WriteField(key, value)
+		} else {
+			queryValuesWriter.Set(key, value)
 		}
-		callback(req)
-	})
+	}
+
+	// Guess content-type
+	var contentType string
+	if multipartWriter != nil {
+		multipartWriter.Close()
+		contentType = multipartWriter.FormDataContentType()
+	} else {
+		contentType = encType
+	}
+
+	req := navigation.NavigationRequest{
+		Method: method,
+		URL:    actionURL,
+		Depth:  resp.Depth,
+		Source: source,
+		Tag:    tag,
+	}
+	if multipartWriter != nil {
+		req.Body = sb.String()
+	} else {
+		req.Body = queryValuesWriter.Encode()
+	}
+	switch method {
+	case "GET":
+		value := queryValuesWriter.Encode()
+		sb.Reset()
+		sb.WriteString(req.URL)
+		sb.WriteString("?")
+		sb.WriteString(value)
+		req.URL = sb.String()
+	case "POST":
+		req.Headers = make(map[string]string)
+		req.Headers["Content-Type"] = contentType
+	}
+	return req
 }
 
 // bodyMetaContentTagParser parses meta content tag from response
-func bodyMetaContentTagParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func bodyMetaContentTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	resp.Reader.Find("meta[http-equiv='refresh']").Each(func(i int, item *goquery.Selection) {
 		header, ok := item.Attr("content")
 		if !ok {
@@ -281,16 +344,121 @@ func bodyMetaContentTagParser(resp navigation.NavigationResponse, callback func(
 		if values == "" {
 			return
 		}
-		callback(navigation.NewNavigationRequestURL(values, "meta", resp))
+		if resolved := resolveHref(base, values); resolved != "" {
+			callback(newRequest(resolved, "meta", resp))
+		}
 	})
 }
 
+// bodyBaseHrefTagParser parses the base href tag from response. The base
+// URL itself is resolved once per document in documentBaseURL and threaded
+// to every other body parser; this parser only emits it as a discovered
+// navigation request.
+func bodyBaseHrefTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	href, ok := resp.Reader.Find("base[href]").First().Attr("href")
+	if !ok || href == "" {
+		return
+	}
+	callback(navigation.NewNavigationRequestURL(href, "base", resp))
+}
+
+// bodyImgSrcsetTagParser parses img/source/picture srcset candidates from response
+func bodyImgSrcsetTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	resp.Reader.Find("img[srcset], source[srcset]").Each(func(i int, item *goquery.Selection) {
+		srcset, ok := item.Attr("srcset")
+		if !ok || srcset == "" {
+			return
+		}
+		for _, candidate := range parseSrcset(srcset) {
+			if resolved := resolveHref(base, candidate); resolved != "" {
+				callback(newRequest(resolved, "srcset", resp))
+			}
+		}
+	})
+}
+
+// bodyLinkHrefTagParser parses link href tag (stylesheet, preload, prefetch,
+// manifest, icon, etc.) from response
+func bodyLinkHrefTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("link[href]", "href", "link")(resp, base, callback)
+}
+
+// bodyObjectDataTagParser parses object data tag from response
+func bodyObjectDataTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("object[data]", "data", "object")(resp, base, callback)
+}
+
+// bodyAppletTagParser parses applet code/codebase tags from response
+func bodyAppletTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	resp.Reader.Find("applet").Each(func(i int, item *goquery.Selection) {
+		if code, ok := item.Attr("code"); ok {
+			if resolved := resolveHref(base, code); resolved != "" {
+				callback(newRequest(resolved, "applet", resp))
+			}
+		}
+		if codebase, ok := item.Attr("codebase"); ok {
+			if resolved := resolveHref(base, codebase); resolved != "" {
+				callback(newRequest(resolved, "applet", resp))
+			}
+		}
+	})
+}
+
+// bodyMediaSrcTagParser parses audio/video/track src tags from response
+func bodyMediaSrcTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("audio[src], video[src], track[src]", "src", "media")(resp, base, callback)
+}
+
+// bodyAreaHrefTagParser parses area href tag from image maps in response
+func bodyAreaHrefTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	findAttrParser("area[href]", "href", "area")(resp, base, callback)
+}
+
+// bodyMetaCSPTagParser parses Content-Security-Policy report-uri/report-to
+// endpoints declared via a meta http-equiv tag from response
+func bodyMetaCSPTagParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
+	resp.Reader.Find("meta[http-equiv='Content-Security-Policy']").Each(func(i int, item *goquery.Selection) {
+		content, ok := item.Attr("content")
+		if !ok || content == "" {
+			return
+		}
+		for _, directive := range strings.Split(content, ";") {
+			fields := strings.Fields(directive)
+			if len(fields) < 2 {
+				continue
+			}
+			switch strings.ToLower(fields[0]) {
+			case "report-uri", "report-to":
+				for _, endpoint := range fields[1:] {
+					if resolved := resolveHref(base, endpoint); resolved != "" {
+						callback(newRequest(resolved, "csp-report", resp))
+					}
+				}
+			}
+		}
+	})
+}
+
+// parseSrcset splits a srcset attribute value into its candidate URLs,
+// stripping pixel-density/width descriptors (e.g. "2x", "100w").
+func parseSrcset(value string) []string {
+	var urls []string
+	for _, candidate := range strings.Split(value, ",") {
+		fields := strings.Fields(strings.TrimSpace(candidate))
+		if len(fields) == 0 {
+			continue
+		}
+		urls = append(urls, fields[0])
+	}
+	return urls
+}
+
 // -------------------------------------------------------------------------
 // Begin JS Regex based parsers
 // -------------------------------------------------------------------------
 
 // scriptContentRegexParser parses script content endpoints from response
-func scriptContentRegexParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func scriptContentRegexParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	resp.Reader.Find("script").Each(func(i int, item *goquery.Selection) {
 		if !resp.Options.Options.ScrapeJSResponses { // do not process if disabled
 			return
@@ -301,13 +469,15 @@ func scriptContentRegexParser(resp navigation.NavigationResponse, callback func(
 		}
 		endpoints := utils.ExtractRelativeEndpoints(text)
 		for _, item := range endpoints {
-			callback(navigation.NewNavigationRequestURL(item, "script-content", resp))
+			if resolved := resolveHref(base, item); resolved != "" {
+				callback(newRequest(resolved, "script-content", resp))
+			}
 		}
 	})
 }
 
 // scriptJSFileRegexParser parses relative endpoints from js file pages
-func scriptJSFileRegexParser(resp navigation.NavigationResponse, callback func(navigation.NavigationRequest)) {
+func scriptJSFileRegexParser(resp navigation.NavigationResponse, base *url.URL, callback func(navigation.NavigationRequest)) {
 	if !resp.Options.Options.ScrapeJSResponses { // do not process if disabled
 		return
 	}
@@ -320,6 +490,8 @@ func scriptJSFileRegexParser(resp navigation.NavigationResponse, callback func(n
 
 	endpoints := utils.ExtractRelativeEndpoints(string(resp.Body))
 	for _, item := range endpoints {
-		callback(navigation.NewNavigationRequestURL(item, "js-file", resp))
+		if resolved := resolveHref(base, item); resolved != "" {
+			callback(newRequest(resolved, "js-file", resp))
+		}
 	}
-}
\ No newline at end of file
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/pkg/errors"
@@ -14,8 +15,50 @@ import (
 	"github.com/projectdiscovery/retryablehttp-go"
 )
 
-// makeRequest makes a request to a URL returning a response interface.
-func (c *Crawler) makeRequest(request navigation.NavigationRequest) (navigation.NavigationResponse, error) {
+// resumeStores caches the opened Store instances per cache path so that a
+// single crawl reuses the same underlying database across requests.
+// resumeStoresMu guards opening a not-yet-cached path: bbolt.Open blocks
+// indefinitely on a conflicting flock, so concurrent requests racing to
+// look up the same path in resumeStores must not each call NewBoltStore.
+var (
+	resumeStoresMu sync.Mutex
+	resumeStores   = make(map[string]Store)
+)
+
+// resumeStore returns the persistent Store configured for this crawler,
+// lazily opening it on first use. It returns nil when incremental crawling
+// isn't enabled via Options.Incremental/Options.ResumeCache.
+func (c *Crawler) resumeStore() Store {
+	if !c.options.Options.Incremental || c.options.Options.ResumeCache == "" {
+		return nil
+	}
+	path := c.options.Options.ResumeCache
+
+	resumeStoresMu.Lock()
+	defer resumeStoresMu.Unlock()
+
+	if store, ok := resumeStores[path]; ok {
+		return store
+	}
+	store, err := NewBoltStore(path)
+	if err != nil {
+		return nil
+	}
+	resumeStores[path] = store
+	return store
+}
+
+// makeRequest makes a request to a URL returning a response interface. When
+// incremental crawling is enabled and a cached entry exists for the URL, it
+// sends a conditional GET and, on a 304 response, short-circuits by
+// re-emitting the cached outlinks through callback instead of re-parsing
+// the body. With incremental crawling enabled, any other response (except a
+// 404) has its body parsed exactly once here, feeding discovered outlinks to
+// callback and recording them on the cache entry in the same pass. With
+// incremental crawling disabled, makeRequest does not parse the body.
+func (c *Crawler) makeRequest(request navigation.NavigationRequest, callback func(navigation.NavigationRequest)) (navigation.NavigationResponse, error) {
+	c.ensureSeedPreCrawl(request.URL, callback)
+
 	response := navigation.NavigationResponse{
 		Depth:   request.Depth + 1,
 		Options: c.options,
@@ -33,6 +76,21 @@ func (c *Crawler) makeRequest(request navigation.NavigationRequest) (navigation.
 	}
 	req.Header.Set("User-Agent", utils.WebUserAgent())
 
+	store := c.resumeStore()
+	cacheKey := canonicalizeURL(request.URL)
+	var cached *CacheEntry
+	if store != nil {
+		if entry, ok := store.Get(cacheKey); ok {
+			cached = entry
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
 	// Set the headers for the request.
 	for k, v := range request.Headers {
 		req.Header.Set(k, v)
@@ -54,6 +112,12 @@ func (c *Crawler) makeRequest(request navigation.NavigationRequest) (navigation.
 	if resp.StatusCode == 404 {
 		return response, nil
 	}
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		for _, outlink := range cached.Outlinks {
+			callback(navigation.NavigationRequest{Method: http.MethodGet, URL: outlink, Depth: response.Depth, Source: "resume-cache"})
+		}
+		return response, nil
+	}
 	limitReader := io.LimitReader(resp.Body, int64(c.options.Options.BodyReadSize))
 	data, err := ioutil.ReadAll(limitReader)
 	if err != nil {
@@ -65,5 +129,18 @@ func (c *Crawler) makeRequest(request navigation.NavigationRequest) (navigation.
 	if err != nil {
 		return response, errors.Wrap(err, "could not make document from reader")
 	}
+
+	if store != nil {
+		entry := &CacheEntry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			BodyHash:     bodySHA256(data),
+		}
+		parseResponse(response, func(outlink navigation.NavigationRequest) {
+			entry.Outlinks = append(entry.Outlinks, outlink.URL)
+			callback(outlink)
+		})
+		_ = store.Put(cacheKey, entry)
+	}
 	return response, nil
 }
\ No newline at end of file
@@ -0,0 +1,111 @@
+package standard
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/projectdiscovery/katana/pkg/navigation"
+	"github.com/projectdiscovery/katana/pkg/utils"
+	"github.com/projectdiscovery/katana/pkg/utils/scope"
+)
+
+// defaultSitemapPaths are fetched by convention for every seed host in
+// addition to whatever sitemaps robots.txt itself declares.
+var defaultSitemapPaths = []string{"/sitemap.xml", "/sitemap_index.xml"}
+
+// preCrawledHosts tracks which hosts have already gone through the
+// robots.txt/sitemap pre-crawl phase so makeRequest only triggers it once
+// per host, the first time that host is requested.
+var (
+	preCrawledHostsMu sync.Mutex
+	preCrawledHosts   = make(map[string]struct{})
+)
+
+// ensureSeedPreCrawl runs parseSeedRobotsAndSitemaps the first time
+// requestURL's host is seen, since this package has no dedicated
+// seed-enqueue step: makeRequest is the only entry point a host's first
+// request passes through.
+func (c *Crawler) ensureSeedPreCrawl(requestURL string, callback func(navigation.NavigationRequest)) {
+	parsed, err := url.Parse(requestURL)
+	if err != nil || parsed.Hostname() == "" {
+		return
+	}
+	host := parsed.Hostname()
+
+	preCrawledHostsMu.Lock()
+	if _, ok := preCrawledHosts[host]; ok {
+		preCrawledHostsMu.Unlock()
+		return
+	}
+	preCrawledHosts[host] = struct{}{}
+	preCrawledHostsMu.Unlock()
+
+	c.parseSeedRobotsAndSitemaps(parsed, callback)
+}
+
+// parseSeedRobotsAndSitemaps runs the robots.txt / sitemap.xml pre-crawl
+// phase for a seed host: it fetches /robots.txt, registers its Disallow
+// rules with the scope manager when -respect-robots is enabled, and feeds
+// every URL discovered through robots-declared and conventional sitemaps
+// into the crawl queue.
+func (c *Crawler) parseSeedRobotsAndSitemaps(seed *url.URL, callback func(navigation.NavigationRequest)) {
+	sitemaps := c.parseRobotsTxt(seed, callback)
+
+	seen := make(map[string]struct{})
+	for _, sitemap := range append(sitemaps, defaultSitemapPaths...) {
+		sitemapURL := resolveReference(seed, sitemap)
+		if sitemapURL == "" {
+			continue
+		}
+		c.parseSitemap(sitemapURL, seen, callback)
+	}
+}
+
+// parseRobotsTxt fetches and parses /robots.txt for a seed host, emitting
+// it as a navigation request and returning the Sitemap entries it declares.
+func (c *Crawler) parseRobotsTxt(seed *url.URL, callback func(navigation.NavigationRequest)) []string {
+	robotsURL := resolveReference(seed, "/robots.txt")
+	if robotsURL == "" {
+		return nil
+	}
+
+	resp, err := c.httpclient.Get(robotsURL)
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_, _ = io.CopyN(ioutil.Discard, resp.Body, 8*1024)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, int64(c.options.Options.BodyReadSize)))
+	if err != nil {
+		return nil
+	}
+
+	disallow, sitemaps := scope.ParseRobotsTxt(bytes.NewReader(body), utils.WebUserAgent())
+	if c.options.Options.RespectRobots {
+		c.options.ScopeManager.SetRespectRobots(true)
+		c.options.ScopeManager.AddRobotsRules(seed.Hostname(), disallow)
+	}
+
+	callback(navigation.NavigationRequest{Method: http.MethodGet, URL: robotsURL, Source: "robots"})
+	return sitemaps
+}
+
+// resolveReference resolves a possibly-relative reference against a seed
+// URL, returning an empty string if it cannot be parsed.
+func resolveReference(seed *url.URL, ref string) string {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return seed.ResolveReference(parsed).String()
+}
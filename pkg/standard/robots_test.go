@@ -0,0 +1,85 @@
+package standard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/projectdiscovery/katana/pkg/navigation"
+	"github.com/projectdiscovery/katana/pkg/types"
+	"github.com/projectdiscovery/katana/pkg/utils/scope"
+	"github.com/projectdiscovery/retryablehttp-go"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMakeRequestRunsSeedPreCrawlPhase verifies that the first request made
+// against a host triggers the robots.txt/sitemap pre-crawl phase: robots.txt
+// Disallow rules are registered with the scope manager and sitemap.xml's
+// URLs are fed into the crawl callback, all without a dedicated seed-enqueue
+// step.
+func TestMakeRequestRunsSeedPreCrawlPhase(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\nSitemap: /sitemap.xml\n"))
+	})
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<urlset><url><loc>http://` + r.Host + `/page1</loc></url></urlset>`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html></html>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	scopeManager, err := scope.NewManager(nil, nil, true)
+	require.NoError(t, err, "could not create scope manager")
+
+	crawler := &Crawler{
+		options: &types.CrawlerOptions{
+			Options: &types.Options{
+				RespectRobots: true,
+				BodyReadSize:  1 << 20,
+			},
+			ScopeManager: scopeManager,
+		},
+		httpclient: retryablehttp.NewClient(retryablehttp.DefaultOptionsSingle),
+	}
+
+	seed, err := url.Parse(server.URL + "/")
+	require.NoError(t, err, "could not parse seed URL")
+
+	var discovered []navigation.NavigationRequest
+	callback := func(req navigation.NavigationRequest) {
+		discovered = append(discovered, req)
+	}
+
+	_, err = crawler.makeRequest(navigation.NavigationRequest{Method: http.MethodGet, URL: seed.String()}, callback)
+	require.NoError(t, err, "makeRequest on the seed should succeed")
+
+	var sawRobots, sawSitemapPage bool
+	for _, req := range discovered {
+		switch req.Source {
+		case "robots":
+			sawRobots = true
+		case "sitemap":
+			if req.URL == server.URL+"/page1" {
+				sawSitemapPage = true
+			}
+		}
+	}
+	require.True(t, sawRobots, "expected robots.txt to be enqueued as a navigation request")
+	require.True(t, sawSitemapPage, "expected the sitemap's URL to be enqueued")
+
+	allowed, err := scopeManager.Validate(&url.URL{Scheme: seed.Scheme, Host: seed.Host, Path: "/private/secret"})
+	require.NoError(t, err, "Validate should not error")
+	require.False(t, allowed, "path disallowed by robots.txt should no longer validate once the pre-crawl phase has run")
+
+	// A second request to the same host must not re-run the pre-crawl phase.
+	discovered = nil
+	_, err = crawler.makeRequest(navigation.NavigationRequest{Method: http.MethodGet, URL: seed.String() + "other"}, callback)
+	require.NoError(t, err, "second makeRequest should succeed")
+	for _, req := range discovered {
+		require.NotEqual(t, "robots", req.Source, "pre-crawl phase should only run once per host")
+	}
+}
@@ -0,0 +1,95 @@
+package standard
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/projectdiscovery/katana/pkg/navigation"
+)
+
+// sitemapURLSet mirrors the sitemaps.org schema for a regular sitemap.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single <url> entry of a sitemap.
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+// sitemapIndex mirrors the sitemaps.org schema for a sitemap index that
+// references further sitemaps.
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+// sitemapEntry is a single <sitemap> entry of a sitemap index.
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// parseSitemap fetches a sitemap, or a sitemap index, transparently
+// handling gzip compressed sitemaps, and feeds every URL it contains into
+// the crawl queue. Sitemap indexes are expanded recursively; seen guards
+// against self-referencing or cyclical sitemap indexes causing unbounded
+// recursion, and is shared across the whole recursive call tree.
+func (c *Crawler) parseSitemap(sitemapURLStr string, seen map[string]struct{}, callback func(navigation.NavigationRequest)) {
+	if _, ok := seen[sitemapURLStr]; ok {
+		return
+	}
+	seen[sitemapURLStr] = struct{}{}
+
+	resp, err := c.httpclient.Get(sitemapURLStr)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_, _ = io.CopyN(ioutil.Discard, resp.Body, 8*1024)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	reader := io.Reader(resp.Body)
+	if strings.HasSuffix(sitemapURLStr, ".gz") || resp.Header.Get("Content-Encoding") == "gzip" {
+		gzReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return
+		}
+		defer gzReader.Close()
+		reader = gzReader
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(reader, int64(c.options.Options.BodyReadSize)))
+	if err != nil {
+		return
+	}
+
+	var urlSet sitemapURLSet
+	if err := xml.Unmarshal(data, &urlSet); err == nil && len(urlSet.URLs) > 0 {
+		for _, item := range urlSet.URLs {
+			if item.Loc == "" {
+				continue
+			}
+			callback(navigation.NavigationRequest{Method: http.MethodGet, URL: item.Loc, Source: "sitemap"})
+		}
+		return
+	}
+
+	var index sitemapIndex
+	if err := xml.Unmarshal(data, &index); err == nil {
+		for _, item := range index.Sitemaps {
+			if item.Loc == "" {
+				continue
+			}
+			c.parseSitemap(item.Loc, seen, callback)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/projectdiscovery/goflags"
+)
+
+// AddFlags registers the crawler's CLI flags onto flagSet, binding them to
+// options.
+func AddFlags(flagSet *goflags.FlagSet, options *Options) {
+	flagSet.CreateGroup("scope", "Scope",
+		flagSet.BoolVarP(&options.RespectRobots, "respect-robots", "rr", false,
+			"respect robots.txt Disallow rules when validating scope"),
+	)
+	flagSet.CreateGroup("recrawl", "Recrawl",
+		flagSet.BoolVarP(&options.Incremental, "incremental", "ic", false,
+			"enable incremental recrawls using conditional GET against a resume cache"),
+		flagSet.StringVarP(&options.ResumeCache, "resume-cache", "rc", "",
+			"path to the persistent resume cache used for incremental recrawls"),
+	)
+	flagSet.CreateGroup("form-fuzz", "Form Fuzzing",
+		flagSet.StringVarP(&options.FormFuzzWordlist, "form-fuzz-wordlist", "ffw", "",
+			"yaml wordlist of payloads to fan form submissions out with"),
+		flagSet.IntVarP(&options.FormFuzzMax, "form-fuzz-max", "ffm", 0,
+			"maximum number of fuzzed submissions emitted per form (0 = unlimited)"),
+	)
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"github.com/projectdiscovery/katana/pkg/utils/scope"
+)
+
+// HeaderMap is a simple custom-header collection bound to the
+// -H/-headers CLI flag.
+type HeaderMap map[string]string
+
+// AsMap converts the header collection into a map[string]interface{},
+// matching the shape request.go expects when assigning request headers.
+func (h HeaderMap) AsMap() map[string]interface{} {
+	values := make(map[string]interface{}, len(h))
+	for k, v := range h {
+		values[k] = v
+	}
+	return values
+}
+
+// Options contains the crawler configuration populated from CLI flags.
+type Options struct {
+	// ScrapeJSResponses enables extraction of relative endpoints from JS
+	// files and inline scripts.
+	ScrapeJSResponses bool
+	// BodyReadSize is the maximum response body size read by the crawler.
+	BodyReadSize int
+	// CustomHeaders is the set of extra headers sent with every request.
+	CustomHeaders HeaderMap
+
+	// RespectRobots enables honoring robots.txt Disallow rules during scope
+	// validation, bound to the -respect-robots CLI flag.
+	RespectRobots bool
+
+	// Incremental enables conditional-GET based incremental recrawls
+	// against the ResumeCache, bound to the -incremental CLI flag.
+	Incremental bool
+	// ResumeCache is the path to the persistent resume cache used for
+	// incremental recrawls, bound to the -resume-cache CLI flag.
+	ResumeCache string
+
+	// FormFuzzWordlist is the path to a YAML wordlist used to fan out form
+	// submissions, bound to the -form-fuzz-wordlist CLI flag.
+	FormFuzzWordlist string
+	// FormFuzzMax caps the number of fuzzed submissions emitted per form
+	// (0 = unlimited), bound to the -form-fuzz-max CLI flag.
+	FormFuzzMax int
+}
+
+// CrawlerOptions wraps the user supplied Options with shared state used
+// across a crawl, such as the scope manager.
+type CrawlerOptions struct {
+	Options      *Options
+	ScopeManager *scope.Manager
+}
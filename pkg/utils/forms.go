@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FormInput represents a single fillable field extracted from an HTML
+// form, covering <input>, <select> and <textarea> elements. Options holds
+// the enumerated <option> values for select elements.
+type FormInput struct {
+	Name    string
+	Type    string
+	Value   string
+	Options []string
+}
+
+// DefaultFormFillData provides generic placeholder values used to fill
+// form fields whose type doesn't already carry an explicit/selected value.
+var DefaultFormFillData = map[string]string{
+	"text":     "katana",
+	"email":    "email@example.com",
+	"password": "katana123",
+	"number":   "1",
+	"tel":      "2124567890",
+	"url":      "https://example.com",
+	"search":   "katana",
+	"date":     "2006-01-02",
+	"color":    "#141414",
+	"textarea": "katana",
+}
+
+// ConvertGoquerySelectionToFormInput converts a goquery selection for an
+// input, select or textarea element into a FormInput. select elements have
+// their <option> values enumerated into Options (preferring the selected
+// one, falling back to the first), and textarea elements use their text
+// content as the default value.
+func ConvertGoquerySelectionToFormInput(item *goquery.Selection) FormInput {
+	name, _ := item.Attr("name")
+	if name == "" {
+		name, _ = item.Attr("id")
+	}
+
+	switch goquery.NodeName(item) {
+	case "select":
+		input := FormInput{Name: name, Type: "select"}
+		item.Find("option").Each(func(i int, option *goquery.Selection) {
+			value, ok := option.Attr("value")
+			if !ok {
+				value = strings.TrimSpace(option.Text())
+			}
+			if value == "" {
+				return
+			}
+			input.Options = append(input.Options, value)
+			if _, selected := option.Attr("selected"); selected && input.Value == "" {
+				input.Value = value
+			}
+		})
+		if input.Value == "" && len(input.Options) > 0 {
+			input.Value = input.Options[0]
+		}
+		return input
+	case "textarea":
+		return FormInput{Name: name, Type: "textarea", Value: strings.TrimSpace(item.Text())}
+	default:
+		inputType, ok := item.Attr("type")
+		if !ok || inputType == "" {
+			inputType = "text"
+		}
+		value, _ := item.Attr("value")
+		return FormInput{Name: name, Type: inputType, Value: value}
+	}
+}
+
+// FormInputFillSuggestions returns a name -> value map suggesting values to
+// fill a form's inputs with. An input's own value (or its selected/first
+// <option> for selects) takes priority, falling back to defaults keyed by
+// the input's type.
+func FormInputFillSuggestions(inputs []FormInput, defaults map[string]string) map[string]string {
+	suggestions := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		if input.Name == "" {
+			continue
+		}
+		switch {
+		case input.Value != "":
+			suggestions[input.Name] = input.Value
+		case input.Type == "select" && len(input.Options) > 0:
+			suggestions[input.Name] = input.Options[0]
+		case defaults[input.Type] != "":
+			suggestions[input.Name] = defaults[input.Type]
+		default:
+			suggestions[input.Name] = defaults["text"]
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,137 @@
+package scope
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ParseRobotsTxt parses the body of a robots.txt file, returning the
+// Disallow rules applicable to userAgent (falling back to the wildcard "*"
+// group if no dedicated group exists for it) along with any Sitemap
+// entries declared anywhere in the file.
+func ParseRobotsTxt(body io.Reader, userAgent string) (disallow []string, sitemaps []string) {
+	scanner := bufio.NewScanner(body)
+
+	var currentGroup string
+	var hasMatchedGroup bool
+	var matchedGroup []string
+	var wildcardGroup []string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := splitRobotsLine(line)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(field) {
+		case "user-agent":
+			currentGroup = strings.ToLower(value)
+			if currentGroup == strings.ToLower(userAgent) {
+				hasMatchedGroup = true
+			}
+		case "disallow":
+			if value == "" {
+				continue
+			}
+			switch currentGroup {
+			case strings.ToLower(userAgent):
+				matchedGroup = append(matchedGroup, value)
+			case "*":
+				wildcardGroup = append(wildcardGroup, value)
+			}
+		case "sitemap":
+			sitemaps = append(sitemaps, value)
+		}
+	}
+
+	if hasMatchedGroup {
+		return matchedGroup, sitemaps
+	}
+	return wildcardGroup, sitemaps
+}
+
+// splitRobotsLine splits a single robots.txt "field: value" line.
+func splitRobotsLine(line string) (field, value string, ok bool) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+}
+
+// robotsRules holds the compiled Disallow rules for a single host.
+type robotsRules struct {
+	disallow []*regexp.Regexp
+}
+
+// matches returns true if the given path is disallowed by the rules.
+func (r *robotsRules) matches(p string) bool {
+	for _, item := range r.disallow {
+		if item.MatchString(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileDisallowRules compiles robots.txt Disallow path prefixes (which
+// may use the "*" and trailing "$" robots.txt wildcards) into regexes
+// anchored at the start of the path.
+func compileDisallowRules(rules []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(rules))
+	for _, rule := range rules {
+		anchorEnd := strings.HasSuffix(rule, "$")
+		trimmed := strings.TrimSuffix(rule, "$")
+		pattern := "^" + strings.ReplaceAll(regexp.QuoteMeta(trimmed), `\*`, ".*")
+		if anchorEnd {
+			pattern += "$"
+		}
+		if compiledRule, err := regexp.Compile(pattern); err == nil {
+			compiled = append(compiled, compiledRule)
+		}
+	}
+	return compiled
+}
+
+// SetRespectRobots toggles whether Validate honors cached robots.txt
+// Disallow rules. It is disabled by default.
+func (m *Manager) SetRespectRobots(respect bool) {
+	m.respectRobots = respect
+}
+
+// AddRobotsRules compiles and caches the Disallow rules for a host so that
+// subsequent Validate calls reject disallowed paths for it. Rules are only
+// compiled once per host.
+func (m *Manager) AddRobotsRules(host string, disallow []string) {
+	m.robotsMu.Lock()
+	defer m.robotsMu.Unlock()
+
+	if m.robots == nil {
+		m.robots = make(map[string]*robotsRules)
+	}
+	if _, ok := m.robots[host]; ok {
+		return
+	}
+	m.robots[host] = &robotsRules{disallow: compileDisallowRules(disallow)}
+}
+
+// isDisallowedByRobots returns true if the host has cached robots.txt rules
+// that disallow the URL's path.
+func (m *Manager) isDisallowedByRobots(host, path string) bool {
+	m.robotsMu.RLock()
+	rules, ok := m.robots[host]
+	m.robotsMu.RUnlock()
+	if !ok {
+		return false
+	}
+	if path == "" {
+		path = "/"
+	}
+	return rules.matches(path)
+}
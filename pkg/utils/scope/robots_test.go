@@ -0,0 +1,38 @@
+package scope
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRobotsTxt(t *testing.T) {
+	body := `User-agent: *
+Disallow: /admin
+Disallow: /private/*
+Sitemap: https://example.com/sitemap.xml
+Sitemap: https://example.com/sitemap_news.xml
+`
+	disallow, sitemaps := ParseRobotsTxt(strings.NewReader(body), "katana")
+	require.ElementsMatch(t, []string{"/admin", "/private/*"}, disallow)
+	require.ElementsMatch(t, []string{"https://example.com/sitemap.xml", "https://example.com/sitemap_news.xml"}, sitemaps)
+}
+
+func TestManagerRespectRobots(t *testing.T) {
+	manager, err := NewManager(nil, nil, true)
+	require.NoError(t, err, "could not create scope manager")
+	manager.SetRespectRobots(true)
+	manager.AddRobotsRules("example.com", []string{"/admin", "/private/*"})
+
+	parsed, _ := url.Parse("https://example.com/admin/users")
+	validated, err := manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.False(t, validated, "disallowed path should not validate")
+
+	parsed, _ = url.Parse("https://example.com/blog/post")
+	validated, err = manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.True(t, validated, "allowed path should validate")
+}
@@ -0,0 +1,172 @@
+package scope
+
+import (
+	"bytes"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ipRange represents an inclusive start-end IP address range such as
+// 10.0.0.1-10.0.0.50.
+type ipRange struct {
+	start net.IP
+	end   net.IP
+}
+
+// contains returns true if ip falls within the inclusive range.
+func (r ipRange) contains(ip net.IP) bool {
+	start, end, candidate := r.start.To16(), r.end.To16(), ip.To16()
+	if start == nil || end == nil || candidate == nil {
+		return false
+	}
+	return bytes.Compare(candidate, start) >= 0 && bytes.Compare(candidate, end) <= 0
+}
+
+// Manager manages scope for the crawler, validating encountered URLs
+// against a list of in-scope / out-of-scope items.
+type Manager struct {
+	inScope    []*regexp.Regexp
+	outOfScope []*regexp.Regexp
+	nets       []*net.IPNet
+	ranges     []ipRange
+	noScope    bool
+
+	respectRobots bool
+	robotsMu      sync.RWMutex
+	robots        map[string]*robotsRules
+}
+
+// NewManager returns a new scope manager instance from the in-scope and
+// out-of-scope regex items passed. If noScope is true, hosts are
+// considered in-scope by default when no in-scope item is provided.
+func NewManager(inScope, outOfScope []string, noScope bool) (*Manager, error) {
+	return NewManagerWithNets(inScope, outOfScope, nil, noScope)
+}
+
+// NewManagerWithNets returns a new scope manager instance that, in addition
+// to the regular in-scope / out-of-scope regex items, also accepts a list
+// of network items. Each network item is auto-classified as a CIDR block
+// (192.168.1.0/24), an IP range (10.0.0.1-10.0.0.50), or a hostname
+// regex / domain glob, and is treated as an in-scope item accordingly.
+func NewManagerWithNets(inScope, outOfScope, nets []string, noScope bool) (*Manager, error) {
+	manager := &Manager{noScope: noScope}
+
+	for _, item := range inScope {
+		compiled, err := regexp.Compile(item)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compile in-scope regex")
+		}
+		manager.inScope = append(manager.inScope, compiled)
+	}
+	for _, item := range outOfScope {
+		compiled, err := regexp.Compile(item)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compile out-of-scope regex")
+		}
+		manager.outOfScope = append(manager.outOfScope, compiled)
+	}
+	for _, item := range nets {
+		if err := manager.addNetItem(item); err != nil {
+			return nil, err
+		}
+	}
+	return manager, nil
+}
+
+// addNetItem classifies a single network item and adds it to the manager.
+func (m *Manager) addNetItem(item string) error {
+	if _, network, err := net.ParseCIDR(item); err == nil {
+		m.nets = append(m.nets, network)
+		return nil
+	}
+	if start, end, ok := parseIPRange(item); ok {
+		m.ranges = append(m.ranges, ipRange{start: start, end: end})
+		return nil
+	}
+
+	pattern := item
+	if strings.Contains(item, "*") {
+		pattern = globToRegex(item)
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return errors.Wrap(err, "could not compile domain glob/regex item")
+	}
+	m.inScope = append(m.inScope, compiled)
+	return nil
+}
+
+// parseIPRange parses a "start-end" IP range item, returning false if the
+// item isn't a well-formed range.
+func parseIPRange(item string) (net.IP, net.IP, bool) {
+	parts := strings.SplitN(item, "-", 2)
+	if len(parts) != 2 {
+		return nil, nil, false
+	}
+	start := net.ParseIP(strings.TrimSpace(parts[0]))
+	end := net.ParseIP(strings.TrimSpace(parts[1]))
+	if start == nil || end == nil {
+		return nil, nil, false
+	}
+	return start, end, true
+}
+
+// globToRegex converts a simple domain glob (e.g. "*.example.com") to an
+// anchored regular expression.
+func globToRegex(glob string) string {
+	escaped := regexp.QuoteMeta(glob)
+	escaped = strings.ReplaceAll(escaped, `\*`, ".*")
+	return "^" + escaped + "$"
+}
+
+// Validate validates a parsed URL against the scope of the manager,
+// returning true if the URL is in-scope for crawling.
+func (m *Manager) Validate(URL *url.URL) (bool, error) {
+	inScope, err := m.validateHost(URL)
+	if err != nil || !inScope {
+		return inScope, err
+	}
+	if m.respectRobots && m.isDisallowedByRobots(URL.Hostname(), URL.Path) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// validateHost validates a parsed URL's host against the scope of the
+// manager, ignoring robots.txt rules.
+func (m *Manager) validateHost(URL *url.URL) (bool, error) {
+	hostname := URL.Hostname()
+
+	if ip := net.ParseIP(hostname); ip != nil {
+		for _, network := range m.nets {
+			if network.Contains(ip) {
+				return true, nil
+			}
+		}
+		for _, r := range m.ranges {
+			if r.contains(ip) {
+				return true, nil
+			}
+		}
+	}
+
+	for _, item := range m.outOfScope {
+		if item.MatchString(hostname) {
+			return false, nil
+		}
+	}
+	if len(m.inScope) == 0 && len(m.nets) == 0 && len(m.ranges) == 0 {
+		return m.noScope, nil
+	}
+	for _, item := range m.inScope {
+		if item.MatchString(hostname) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
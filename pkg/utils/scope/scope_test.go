@@ -30,4 +30,39 @@ func TestManagerValidate(t *testing.T) {
 	validated, err = manager.Validate(parsed)
 	require.NoError(t, err, "could not validate url")
 	require.True(t, validated, "could not get correct in-scope ip validation")
+}
+
+func TestManagerValidateNets(t *testing.T) {
+	manager, err := NewManagerWithNets(nil, nil, []string{
+		"192.168.1.0/24",
+		"10.0.0.1-10.0.0.50",
+		"*.google.com",
+		"2001:db8::/32",
+	}, false)
+	require.NoError(t, err, "could not create scope manager")
+
+	parsed, _ := url.Parse("https://192.168.1.45")
+	validated, err := manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.True(t, validated, "could not get correct in-scope cidr validation")
+
+	parsed, _ = url.Parse("https://10.0.0.25")
+	validated, err = manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.True(t, validated, "could not get correct in-scope ip range validation")
+
+	parsed, _ = url.Parse("https://10.0.0.60")
+	validated, err = manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.False(t, validated, "could not get correct out-of-range ip validation")
+
+	parsed, _ = url.Parse("https://mail.google.com")
+	validated, err = manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.True(t, validated, "could not get correct in-scope domain glob validation")
+
+	parsed, _ = url.Parse("https://[2001:db8::1]")
+	validated, err = manager.Validate(parsed)
+	require.NoError(t, err, "could not validate url")
+	require.True(t, validated, "could not get correct in-scope ipv6 cidr validation")
 }
\ No newline at end of file